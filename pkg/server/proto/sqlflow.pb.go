@@ -0,0 +1,360 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package proto holds the Go types for sqlflow.proto. It is normally
+// produced by `protoc --go_out=.`; this copy is maintained by hand because
+// this checkout has no protoc, and mirrors the struct/getter shape
+// protoc-gen-go would emit so callers don't need to care which one built it.
+package proto
+
+// Session carries per-request connection and environment state. See
+// sqlflow.proto for the authoritative field documentation.
+type Session struct {
+	Token        string
+	DbConnStr    string
+	ExitOnSubmit bool
+	UserId       string
+
+	HiveLocation     string
+	HdfsNamenodeAddr string
+	HdfsUser         string
+	HdfsPass         string
+
+	SnowflakeConfig *SnowflakeConfig
+
+	// StorageConfig is the storage_config oneof: one of *Session_Hdfs,
+	// *Session_S3, *Session_Gcs, *Session_Oss, or nil.
+	StorageConfig isSession_StorageConfig
+}
+
+type isSession_StorageConfig interface {
+	isSession_StorageConfig()
+}
+
+// Session_Hdfs wraps the HDFSConfig storage_config option.
+type Session_Hdfs struct{ Hdfs *HDFSConfig }
+
+// Session_S3 wraps the S3Config storage_config option.
+type Session_S3 struct{ S3 *S3Config }
+
+// Session_Gcs wraps the GCSConfig storage_config option.
+type Session_Gcs struct{ Gcs *GCSConfig }
+
+// Session_Oss wraps the OSSConfig storage_config option.
+type Session_Oss struct{ Oss *OSSConfig }
+
+func (*Session_Hdfs) isSession_StorageConfig() {}
+func (*Session_S3) isSession_StorageConfig()   {}
+func (*Session_Gcs) isSession_StorageConfig()  {}
+func (*Session_Oss) isSession_StorageConfig()  {}
+
+func (m *Session) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *Session) GetDbConnStr() string {
+	if m != nil {
+		return m.DbConnStr
+	}
+	return ""
+}
+
+func (m *Session) GetExitOnSubmit() bool {
+	if m != nil {
+		return m.ExitOnSubmit
+	}
+	return false
+}
+
+func (m *Session) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *Session) GetHiveLocation() string {
+	if m != nil {
+		return m.HiveLocation
+	}
+	return ""
+}
+
+func (m *Session) GetHdfsNamenodeAddr() string {
+	if m != nil {
+		return m.HdfsNamenodeAddr
+	}
+	return ""
+}
+
+func (m *Session) GetHdfsUser() string {
+	if m != nil {
+		return m.HdfsUser
+	}
+	return ""
+}
+
+func (m *Session) GetHdfsPass() string {
+	if m != nil {
+		return m.HdfsPass
+	}
+	return ""
+}
+
+func (m *Session) GetSnowflakeConfig() *SnowflakeConfig {
+	if m != nil {
+		return m.SnowflakeConfig
+	}
+	return nil
+}
+
+func (m *Session) GetStorageConfig() isSession_StorageConfig {
+	if m != nil {
+		return m.StorageConfig
+	}
+	return nil
+}
+
+// SnowflakeConfig describes how to reach a Snowflake warehouse. See
+// sqlflow.proto for field documentation.
+type SnowflakeConfig struct {
+	Account   string
+	Warehouse string
+	Role      string
+	Database  string
+	Schema    string
+
+	AuthPrivateKey           string
+	AuthPrivateKeyPassphrase string
+	AuthOauthToken           string
+}
+
+func (m *SnowflakeConfig) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *SnowflakeConfig) GetWarehouse() string {
+	if m != nil {
+		return m.Warehouse
+	}
+	return ""
+}
+
+func (m *SnowflakeConfig) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *SnowflakeConfig) GetDatabase() string {
+	if m != nil {
+		return m.Database
+	}
+	return ""
+}
+
+func (m *SnowflakeConfig) GetSchema() string {
+	if m != nil {
+		return m.Schema
+	}
+	return ""
+}
+
+func (m *SnowflakeConfig) GetAuthPrivateKey() string {
+	if m != nil {
+		return m.AuthPrivateKey
+	}
+	return ""
+}
+
+func (m *SnowflakeConfig) GetAuthPrivateKeyPassphrase() string {
+	if m != nil {
+		return m.AuthPrivateKeyPassphrase
+	}
+	return ""
+}
+
+func (m *SnowflakeConfig) GetAuthOauthToken() string {
+	if m != nil {
+		return m.AuthOauthToken
+	}
+	return ""
+}
+
+// HDFSConfig is the storage_config equivalent of the legacy hive_location /
+// hdfs_* fields on Session.
+type HDFSConfig struct {
+	NamenodeAddr string
+	User         string
+	Pass         string
+	HiveLocation string
+}
+
+func (m *HDFSConfig) GetNamenodeAddr() string {
+	if m != nil {
+		return m.NamenodeAddr
+	}
+	return ""
+}
+
+func (m *HDFSConfig) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *HDFSConfig) GetPass() string {
+	if m != nil {
+		return m.Pass
+	}
+	return ""
+}
+
+func (m *HDFSConfig) GetHiveLocation() string {
+	if m != nil {
+		return m.HiveLocation
+	}
+	return ""
+}
+
+// S3Config addresses an S3-compatible bucket.
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	AccessKeyId     string
+	SecretAccessKey string
+	Bucket          string
+	Prefix          string
+}
+
+func (m *S3Config) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *S3Config) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *S3Config) GetAccessKeyId() string {
+	if m != nil {
+		return m.AccessKeyId
+	}
+	return ""
+}
+
+func (m *S3Config) GetSecretAccessKey() string {
+	if m != nil {
+		return m.SecretAccessKey
+	}
+	return ""
+}
+
+func (m *S3Config) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *S3Config) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+// GCSConfig addresses a Google Cloud Storage bucket.
+type GCSConfig struct {
+	ServiceAccountJson string
+	Bucket             string
+	Prefix             string
+}
+
+func (m *GCSConfig) GetServiceAccountJson() string {
+	if m != nil {
+		return m.ServiceAccountJson
+	}
+	return ""
+}
+
+func (m *GCSConfig) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *GCSConfig) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+// OSSConfig addresses an Alibaba Cloud OSS bucket.
+type OSSConfig struct {
+	Endpoint        string
+	AccessKeyId     string
+	AccessKeySecret string
+	Bucket          string
+	Prefix          string
+}
+
+func (m *OSSConfig) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *OSSConfig) GetAccessKeyId() string {
+	if m != nil {
+		return m.AccessKeyId
+	}
+	return ""
+}
+
+func (m *OSSConfig) GetAccessKeySecret() string {
+	if m != nil {
+		return m.AccessKeySecret
+	}
+	return ""
+}
+
+func (m *OSSConfig) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *OSSConfig) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"text/template"
+
+	pb "sqlflow.org/sqlflow/pkg/server/proto"
+)
+
+// genTF writes the TensorFlow Python program for r (a TRAIN or PREDICT
+// statement, verified by verify into fts) to w.
+func genTF(w io.Writer, r *extendedSelect, cc *clusterConfig, fts *fieldTypes, db *DB, sess *pb.Session) error {
+	fl, e := newFiller(r, cc, fts, db, sess)
+	if e != nil {
+		return e
+	}
+	return tfProgramTemplate.Execute(w, fl)
+}
+
+// pyList renders v (a []string or []int) as a Python list literal via
+// JSON, which happens to produce valid Python syntax for both: quoted,
+// comma-separated strings, e.g. ["a", "b"], or comma-separated numbers,
+// e.g. [10, 20]. Go's default %v formatting does neither (space-separated,
+// unquoted), so the template must never interpolate a slice directly.
+//
+// A nil v (e.g. HiddenUnits left unset by an estimator, such as
+// DeepEmbeddingClusterModel, whose schema doesn't require it) renders as
+// "[]", not JSON's "null" - Python has no bare `null`, so that would be an
+// undefined name at run time.
+func pyList(v interface{}) string {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.IsNil() {
+		return "[]"
+	}
+	b, e := json.Marshal(v)
+	if e != nil {
+		// v is always a []string or []int built by this package, so
+		// Marshal cannot fail in practice.
+		panic(e)
+	}
+	return string(b)
+}
+
+var tfProgramTemplate = template.Must(template.New("tfProgram").Funcs(template.FuncMap{
+	"pyList": pyList,
+}).Parse(`
+import tensorflow as tf
+{{if .Snowflake}}import snowflake.connector{{end}}
+
+{{if .Snowflake}}
+conn = {{.SnowflakeConnect}}
+{{if .SnowflakeFetchArrow}}
+{{.SnowflakeArrowFetchSnippet}}
+{{end}}
+{{end}}
+
+{{if .StorageImport}}import {{.StorageImport}}
+artifact_uri = {{printf "%q" .ArtifactURI}}
+{{end}}
+
+{{if .VocabLookupSnippet}}
+{{.VocabLookupSnippet}}
+{{end}}
+
+{{.SchemaComment}}
+# query = {{.ReadQuery}}
+
+{{if .IsTrain}}
+feature_columns = [tf.feature_column.numeric_column(c) for c in {{pyList .Columns}}]
+estimator = tf.estimator.{{.Estimator}}(feature_columns=feature_columns, n_classes={{.NumClasses}}, hidden_units={{pyList .HiddenUnits}})
+# trains against {{.Table}}, saves to {{.ModelSave}}
+{{else}}
+{{if .InverseVocabLookupSnippet}}
+{{.InverseVocabLookupSnippet}}
+{{end}}
+# predicts {{.Table}} into {{.PredictTable}}.{{.PredictColumn}}
+{{end}}
+`))
@@ -0,0 +1,36 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegentest
+
+import (
+	"os"
+	"testing"
+)
+
+// TestCodegenMatrix runs RunCodegenSuite for the dialect named by
+// SQLFLOW_TEST_DIALECT, for a CI job or developer that wants to isolate
+// one dialect, or for the whole Matrix when the variable is unset so a
+// plain `go test ./pkg/sql/...` still exercises every registered dialect.
+func TestCodegenMatrix(t *testing.T) {
+	if d := os.Getenv("SQLFLOW_TEST_DIALECT"); d != "" {
+		RunCodegenSuite(t, Dialect(d))
+		return
+	}
+	for _, d := range Matrix {
+		d := d
+		t.Run(string(d), func(t *testing.T) {
+			RunCodegenSuite(t, d)
+		})
+	}
+}
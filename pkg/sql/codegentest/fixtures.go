@@ -0,0 +1,135 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegentest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	sqlflow "sqlflow.org/sqlflow/pkg/sql"
+)
+
+// irisAndChurnSchema and irisAndChurnRows are the (table -> column ->
+// logical type) schema and fixture rows every dialect's FixtureLoader
+// loads into its sql.NewMemDB, via string(dialect) as the dsn/driverName
+// (see sql.NewMemDB and dialect.go). Sharing them means the matrix
+// differs only in how each dialect's plugin names identifiers and types,
+// not in what data it trains on.
+var irisAndChurnSchema = map[string]map[string]string{
+	"iris.train": {
+		"sepal_length": "float", "sepal_width": "float",
+		"petal_length": "float", "petal_width": "float",
+		"class": "int",
+	},
+	"iris.test": {
+		"sepal_length": "float", "sepal_width": "float",
+		"petal_length": "float", "petal_width": "float",
+		"class": "int",
+	},
+	"churn.train": {"customerID": "string", "gender": "string"},
+	"churn.test":  {"customerID": "string", "gender": "string"},
+}
+
+var irisAndChurnRows = map[string][]map[string]interface{}{
+	"iris.train": {
+		{"sepal_length": 5.1, "sepal_width": 3.5, "petal_length": 1.4, "petal_width": 0.2, "class": int64(0)},
+		{"sepal_length": 7.0, "sepal_width": 3.2, "petal_length": 4.7, "petal_width": 1.4, "class": int64(1)},
+	},
+	"churn.train": {
+		{"customerID": "c1", "gender": "Female"},
+		{"customerID": "c2", "gender": "Male"},
+	},
+}
+
+const irisTrainSQL = `SELECT * FROM iris.train
+TRAIN DNNClassifier
+WITH
+  model.n_classes = 3,
+  model.hidden_units = [10, 20]
+COLUMN sepal_length, sepal_width, petal_length, petal_width
+LABEL class
+INTO sqlflow_models.my_dnn_model;`
+
+const irisPredictSQL = `SELECT * FROM iris.test
+PREDICT iris.predict.class
+USING sqlflow_models.my_dnn_model;`
+
+const churnTrainSQL = `SELECT customerID, gender FROM churn.train
+TRAIN DNNClassifier
+WITH
+  model.n_classes = 3,
+  model.hidden_units = [10, 20]
+COLUMN customerID
+LABEL gender
+INTO sqlflow_models.my_dnn_model;`
+
+const churnPredictSQL = `SELECT customerID, gender FROM churn.test
+PREDICT churn.predict.gender
+USING sqlflow_models.my_dnn_model;`
+
+// runIrisTrainPredict replays the TRAIN/PREDICT pair pkg/sql's
+// TestCodeGenTrain and TestCodeGenPredict exercise, against db (which
+// dialect's FixtureLoader built), through the real parser/verify/genTF
+// pipeline via sql.RunTrainPredict.
+func runIrisTrainPredict(t *testing.T, dialect Dialect, db *sqlflow.DB) {
+	t.Helper()
+	t.Run(string(dialect)+"/iris_train_predict", func(t *testing.T) {
+		var out bytes.Buffer
+		if e := sqlflow.RunTrainPredict(&out, irisTrainSQL, irisPredictSQL, db, nil); e != nil {
+			t.Fatalf("codegentest: iris TRAIN/PREDICT against %s: %v", dialect, e)
+		}
+		if out.Len() == 0 {
+			t.Fatalf("codegentest: iris TRAIN/PREDICT against %s produced no generated code", dialect)
+		}
+	})
+}
+
+// runChurnStringLabel replays the string-LABEL vocabulary fixtures
+// pkg/sql's TestLabelAsStringType and TestPredictStringLabel exercise,
+// against db, through the same pipeline.
+func runChurnStringLabel(t *testing.T, dialect Dialect, db *sqlflow.DB) {
+	t.Helper()
+	t.Run(string(dialect)+"/churn_string_label", func(t *testing.T) {
+		var out bytes.Buffer
+		if e := sqlflow.RunTrainPredict(&out, churnTrainSQL, churnPredictSQL, db, nil); e != nil {
+			t.Fatalf("codegentest: churn string-label TRAIN/PREDICT against %s: %v", dialect, e)
+		}
+		if out.Len() == 0 {
+			t.Fatalf("codegentest: churn string-label TRAIN/PREDICT against %s produced no generated code", dialect)
+		}
+	})
+}
+
+// runDialectRendering replays the iris TRAIN/PREDICT pair against db and
+// asserts the generated PREDICT program actually names its source columns the
+// way quote/types say dialect does - the thing a single-dialect run of
+// TestCodeGen* can never catch, since it only ever sees one dialect's
+// rendering.
+func runDialectRendering(t *testing.T, dialect Dialect, db *sqlflow.DB, quote QuoteIdent, types TypeMapper) {
+	t.Helper()
+	t.Run(string(dialect)+"/dialect_rendering", func(t *testing.T) {
+		var out bytes.Buffer
+		if e := sqlflow.RunTrainPredict(&out, irisTrainSQL, irisPredictSQL, db, nil); e != nil {
+			t.Fatalf("codegentest: iris TRAIN/PREDICT against %s: %v", dialect, e)
+		}
+		got := out.String()
+		if q := quote.Quote("sepal_length"); !strings.Contains(got, q) {
+			t.Errorf("codegentest: %s generated program doesn't quote sepal_length as %s:\n%s", dialect, q, got)
+		}
+		if ty := types.SQLType("float"); !strings.Contains(got, ty) {
+			t.Errorf("codegentest: %s generated program doesn't name iris's float columns %s:\n%s", dialect, ty, got)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegentest
+
+import (
+	sqlflow "sqlflow.org/sqlflow/pkg/sql"
+)
+
+// mysqlFixtures is the FixtureLoader plugin for MySQL.
+type mysqlFixtures struct{}
+
+func (mysqlFixtures) Load(dialect Dialect) (*sqlflow.DB, error) {
+	return sqlflow.NewMemDB(string(dialect), irisAndChurnSchema, irisAndChurnRows)
+}
+
+// mysqlQuoteIdent backtick-quotes identifiers, MySQL's convention.
+type mysqlQuoteIdent struct{}
+
+func (mysqlQuoteIdent) Quote(ident string) string {
+	return "`" + ident + "`"
+}
+
+// mysqlTypeMapper names MySQL's native type for each logical type this
+// package's fixtures use.
+type mysqlTypeMapper struct{}
+
+func (mysqlTypeMapper) SQLType(logical string) string {
+	switch logical {
+	case "float":
+		return "FLOAT"
+	case "string":
+		return "VARCHAR(255)"
+	default:
+		return "INT"
+	}
+}
+
+func init() {
+	Register(MySQL, Registry{
+		Fixtures: mysqlFixtures{},
+		Quote:    mysqlQuoteIdent{},
+		Types:    mysqlTypeMapper{},
+	})
+}
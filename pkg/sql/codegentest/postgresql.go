@@ -0,0 +1,57 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegentest
+
+import (
+	sqlflow "sqlflow.org/sqlflow/pkg/sql"
+)
+
+// postgresqlFixtures is the FixtureLoader plugin for PostgreSQL.
+type postgresqlFixtures struct{}
+
+func (postgresqlFixtures) Load(dialect Dialect) (*sqlflow.DB, error) {
+	return sqlflow.NewMemDB(string(dialect), irisAndChurnSchema, irisAndChurnRows)
+}
+
+// postgresqlQuoteIdent double-quotes identifiers, PostgreSQL's ANSI-SQL
+// convention (as opposed to MySQL/Hive/MaxCompute's backticks).
+type postgresqlQuoteIdent struct{}
+
+func (postgresqlQuoteIdent) Quote(ident string) string {
+	return `"` + ident + `"`
+}
+
+// postgresqlTypeMapper names PostgreSQL's native type for each logical
+// type this package's fixtures use - REAL rather than MySQL's FLOAT, TEXT
+// rather than VARCHAR(255).
+type postgresqlTypeMapper struct{}
+
+func (postgresqlTypeMapper) SQLType(logical string) string {
+	switch logical {
+	case "float":
+		return "REAL"
+	case "string":
+		return "TEXT"
+	default:
+		return "INT"
+	}
+}
+
+func init() {
+	Register(PostgreSQL, Registry{
+		Fixtures: postgresqlFixtures{},
+		Quote:    postgresqlQuoteIdent{},
+		Types:    postgresqlTypeMapper{},
+	})
+}
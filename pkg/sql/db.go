@@ -0,0 +1,86 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import "sort"
+
+// columnType is the logical type of a COLUMN/LABEL source column, as
+// resolved from the database schema.
+type columnType int
+
+const (
+	typeInt columnType = iota
+	typeFloat
+	typeString
+)
+
+func (t columnType) String() string {
+	switch t {
+	case typeInt:
+		return "int"
+	case typeFloat:
+		return "float"
+	case typeString:
+		return "string"
+	default:
+		return "unknown"
+	}
+}
+
+// DB is a handle to the database holding the tables a TRAIN/PREDICT
+// statement reads from. verify uses it to resolve COLUMN/LABEL types and,
+// for a string LABEL, to scan the column's distinct values.
+type DB struct {
+	driverName string
+	dsn        string
+	schema     map[string]map[string]columnType
+	rows       map[string][]map[string]interface{}
+}
+
+// String returns the DSN this DB was opened with, as stored on
+// pb.Session.DbConnStr.
+func (db *DB) String() string {
+	return db.dsn
+}
+
+// column returns the logical type of table.column, and whether it exists.
+func (db *DB) column(table, column string) (columnType, bool) {
+	cols, ok := db.schema[table]
+	if !ok {
+		return 0, false
+	}
+	t, ok := cols[column]
+	return t, ok
+}
+
+// distinctStrings returns the sorted, de-duplicated values of table.column.
+// It's used to build the vocabulary for a string LABEL column.
+func (db *DB) distinctStrings(table, column string) []string {
+	seen := map[string]bool{}
+	var vocab []string
+	for _, row := range db.rows[table] {
+		v, ok := row[column]
+		if !ok {
+			continue
+		}
+		s, ok := v.(string)
+		if !ok || seen[s] {
+			continue
+		}
+		seen[s] = true
+		vocab = append(vocab, s)
+	}
+	sort.Strings(vocab)
+	return vocab
+}
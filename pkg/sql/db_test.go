@@ -0,0 +1,59 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+// testDB is the in-memory fixture the codegen tests verify/genTF/newFiller
+// against: an iris table pair (numeric LABEL) and a churn table pair
+// (string LABEL), so both code paths in verify have something to chew on
+// without a real database server in the test environment.
+var testDB = &DB{
+	driverName: "test",
+	dsn:        "test://testDB",
+	schema: map[string]map[string]columnType{
+		"iris.train": {
+			"sepal_length": typeFloat,
+			"sepal_width":  typeFloat,
+			"petal_length": typeFloat,
+			"petal_width":  typeFloat,
+			"class":        typeInt,
+		},
+		"iris.test": {
+			"sepal_length": typeFloat,
+			"sepal_width":  typeFloat,
+			"petal_length": typeFloat,
+			"petal_width":  typeFloat,
+			"class":        typeInt,
+		},
+		"churn.train": {
+			"customerID": typeString,
+			"gender":     typeString,
+		},
+		"churn.test": {
+			"customerID": typeString,
+			"gender":     typeString,
+		},
+	},
+	rows: map[string][]map[string]interface{}{
+		"iris.train": {
+			{"sepal_length": 5.1, "sepal_width": 3.5, "petal_length": 1.4, "petal_width": 0.2, "class": int64(0)},
+			{"sepal_length": 7.0, "sepal_width": 3.2, "petal_length": 4.7, "petal_width": 1.4, "class": int64(1)},
+			{"sepal_length": 6.3, "sepal_width": 3.3, "petal_length": 6.0, "petal_width": 2.5, "class": int64(2)},
+		},
+		"churn.train": {
+			{"customerID": "c1", "gender": "Female"},
+			{"customerID": "c2", "gender": "Male"},
+			{"customerID": "c3", "gender": "Female"},
+		},
+	},
+}
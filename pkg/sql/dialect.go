@@ -0,0 +1,104 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dialect identifies the SQL engine db.driverName names, so genTF can
+// render identifiers and native type names the way that engine expects
+// instead of one fixed syntax for every backend.
+type dialect string
+
+const (
+	dialectMySQL      dialect = "mysql"
+	dialectHive       dialect = "hive"
+	dialectPostgreSQL dialect = "postgresql"
+	dialectMaxCompute dialect = "maxcompute"
+)
+
+// quoteIdent quotes ident per d's convention: MySQL, Hive and MaxCompute
+// use backticks; PostgreSQL and anything else not listed above (including
+// the "test" dialect this package's own testDB uses) fall back to ANSI
+// double quotes.
+func quoteIdent(d dialect, ident string) string {
+	switch d {
+	case dialectMySQL, dialectHive, dialectMaxCompute:
+		return "`" + ident + "`"
+	default:
+		return `"` + ident + `"`
+	}
+}
+
+// quoteTable quotes each dot-separated segment of a dotted table
+// reference like "iris.train" individually, e.g. "`iris`.`train`" for
+// MySQL or `"iris"."train"` for PostgreSQL.
+func quoteTable(d dialect, table string) string {
+	parts := strings.Split(table, ".")
+	for i, p := range parts {
+		parts[i] = quoteIdent(d, p)
+	}
+	return strings.Join(parts, ".")
+}
+
+// sqlType names t's native SQL type for d. The engines this package's
+// dialect matrix targets disagree enough on basic type names (FLOAT vs
+// REAL, VARCHAR vs STRING) that a generated program documenting its
+// source schema has to pick per dialect rather than printing
+// columnType.String().
+func sqlType(d dialect, t columnType) string {
+	switch t {
+	case typeInt:
+		return "INT"
+	case typeFloat:
+		if d == dialectPostgreSQL {
+			return "REAL"
+		}
+		return "FLOAT"
+	case typeString:
+		switch d {
+		case dialectPostgreSQL:
+			return "TEXT"
+		case dialectHive:
+			return "STRING"
+		default:
+			return "VARCHAR(255)"
+		}
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// readQuery renders the SELECT statement genTF documents in the generated
+// program for reading columns from table, quoting identifiers per d.
+func readQuery(d dialect, table string, columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = quoteIdent(d, c)
+	}
+	return fmt.Sprintf("SELECT %s FROM %s", strings.Join(quoted, ", "), quoteTable(d, table))
+}
+
+// schemaComment renders the "# source columns (dialect): ..." comment
+// genTF embeds in the generated program, naming each column's native SQL
+// type for d.
+func schemaComment(d dialect, columns []string, types map[string]columnType) string {
+	parts := make([]string, len(columns))
+	for i, c := range columns {
+		parts[i] = fmt.Sprintf("%s %s", quoteIdent(d, c), sqlType(d, types[c]))
+	}
+	return fmt.Sprintf("# source columns (%s): %s", d, strings.Join(parts, ", "))
+}
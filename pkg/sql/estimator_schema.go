@@ -0,0 +1,204 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema"
+)
+
+// estimatorSchemas holds the JSON Schema for each estimator's model.* and
+// train.* WITH attributes, keyed by the estimator name as it appears in a
+// TRAIN clause (e.g. "DNNClassifier", "sqlflow_models.DeepEmbeddingClusterModel").
+var estimatorSchemas = struct {
+	sync.RWMutex
+	m map[string]*jsonschema.Schema
+}{m: map[string]*jsonschema.Schema{}}
+
+// RegisterEstimatorSchema compiles schema and registers it for estimator
+// name, so that verify validates any WITH attributes a TRAIN clause sets
+// for that estimator before codegen runs. Third-party estimators (anything
+// under sqlflow_models or a user's own package) call this from an init
+// function to opt into the same validation built-in estimators get.
+func RegisterEstimatorSchema(name string, schema []byte) error {
+	c := jsonschema.NewCompiler()
+	if e := c.AddResource(name+".json", bytes.NewReader(schema)); e != nil {
+		return fmt.Errorf("compile schema for estimator %s: %v", name, e)
+	}
+	s, e := c.Compile(name + ".json")
+	if e != nil {
+		return fmt.Errorf("compile schema for estimator %s: %v", name, e)
+	}
+	estimatorSchemas.Lock()
+	defer estimatorSchemas.Unlock()
+	estimatorSchemas.m[name] = s
+	return nil
+}
+
+// validateEstimatorAttrs validates the model.* and train.* WITH attributes
+// parsed for a TRAIN clause against the registered schema for estimator,
+// returning nil if no schema is registered (estimators opt in, they aren't
+// required to ship a schema). attrs maps a WITH key such as "model.n_classes"
+// to its parsed value.
+func validateEstimatorAttrs(estimator string, attrs map[string]interface{}, attrPos map[string]sourcePos) error {
+	estimatorSchemas.RLock()
+	s, ok := estimatorSchemas.m[estimator]
+	estimatorSchemas.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	doc, e := attrsToJSONDocument(attrs)
+	if e != nil {
+		return e
+	}
+	if e := s.Validate(bytes.NewReader(doc)); e != nil {
+		return fmt.Errorf("WITH attributes for %s are invalid: %s", estimator, describeValidationError(e, attrPos))
+	}
+	return nil
+}
+
+// describeValidationError turns a *jsonschema.ValidationError into a
+// message naming the offending WITH attribute by its dotted key (e.g.
+// "model.n_classes must be >= 2 but found -3") instead of the library's
+// json-pointer-rooted one ("#/model/n_classes"), since dotted keys are
+// what a TRAIN WITH clause actually looks like. When attrPos (from
+// trainClause.attrPos) has an entry for that key, its source location is
+// appended too, e.g. "model.n_classes must be >= 2 but found -3 (line 4,
+// column 3)".
+func describeValidationError(e error, attrPos map[string]sourcePos) string {
+	ve, ok := e.(*jsonschema.ValidationError)
+	if !ok {
+		return e.Error()
+	}
+	leaves := leafValidationErrors(ve)
+	if len(leaves) == 0 {
+		return ve.Error()
+	}
+	parts := make([]string, len(leaves))
+	for i, l := range leaves {
+		attr := instancePtrToAttr(l.InstancePtr)
+		if pos, ok := attrPos[attr]; ok {
+			parts[i] = fmt.Sprintf("%s %s (%s)", attr, l.Message, pos)
+		} else {
+			parts[i] = fmt.Sprintf("%s %s", attr, l.Message)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// leafValidationErrors flattens a jsonschema.ValidationError's Causes tree
+// into the individual property failures at its leaves.
+func leafValidationErrors(ve *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(ve.Causes) == 0 {
+		return []*jsonschema.ValidationError{ve}
+	}
+	var out []*jsonschema.ValidationError
+	for _, c := range ve.Causes {
+		out = append(out, leafValidationErrors(c)...)
+	}
+	return out
+}
+
+// instancePtrToAttr turns a json-pointer like "#/model/n_classes" into the
+// dotted WITH attribute key "model.n_classes".
+func instancePtrToAttr(ptr string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(ptr, "#/"), "/", ".")
+}
+
+// attrsToJSONDocument turns the flat "model.n_classes" -> value attribute
+// map the parser produces into the nested {"model": {"n_classes": ...}}
+// document shape the estimator schemas are written against.
+func attrsToJSONDocument(attrs map[string]interface{}) ([]byte, error) {
+	doc := map[string]interface{}{}
+	for k, v := range attrs {
+		group, key := splitAttrKey(k)
+		sub, ok := doc[group].(map[string]interface{})
+		if !ok {
+			sub = map[string]interface{}{}
+			doc[group] = sub
+		}
+		sub[key] = v
+	}
+	return json.Marshal(doc)
+}
+
+// splitAttrKey splits a WITH key like "model.n_classes" into its group
+// ("model") and the remaining key ("n_classes"); a key with no "." is
+// returned wholesale as its own group.
+func splitAttrKey(k string) (group, key string) {
+	for i := 0; i < len(k); i++ {
+		if k[i] == '.' {
+			return k[:i], k[i+1:]
+		}
+	}
+	return k, k
+}
+
+func init() {
+	if e := RegisterEstimatorSchema("DNNClassifier", []byte(dnnClassifierSchema)); e != nil {
+		panic(e)
+	}
+	if e := RegisterEstimatorSchema("sqlflow_models.DeepEmbeddingClusterModel", []byte(deepEmbeddingClusterModelSchema)); e != nil {
+		panic(e)
+	}
+}
+
+const dnnClassifierSchema = `{
+  "type": "object",
+  "properties": {
+    "model": {
+      "type": "object",
+      "properties": {
+        "n_classes": {"type": "integer", "minimum": 2},
+        "hidden_units": {"type": "array", "items": {"type": "integer", "minimum": 1}, "minItems": 1}
+      },
+      "required": ["n_classes", "hidden_units"]
+    },
+    "train": {
+      "type": "object",
+      "properties": {
+        "batch_size": {"type": "integer", "minimum": 1},
+        "epoch": {"type": "integer", "minimum": 1}
+      }
+    }
+  }
+}`
+
+const deepEmbeddingClusterModelSchema = `{
+  "type": "object",
+  "properties": {
+    "model": {
+      "type": "object",
+      "properties": {
+        "n_clusters": {"type": "integer", "minimum": 1},
+        "pretrain_dims": {"type": "array", "items": {"type": "integer", "minimum": 1}, "minItems": 1},
+        "pretrain_lr": {"type": "number", "exclusiveMinimum": 0}
+      },
+      "required": ["n_clusters", "pretrain_dims"]
+    },
+    "train": {
+      "type": "object",
+      "properties": {
+        "batch_size": {"type": "integer", "minimum": 1}
+      },
+      "required": ["batch_size"]
+    }
+  }
+}`
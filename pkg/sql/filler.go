@@ -0,0 +1,157 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	pb "sqlflow.org/sqlflow/pkg/server/proto"
+)
+
+// filler holds everything the TensorFlow code generation template needs.
+// newFiller builds one from the parsed statement, verify's fieldTypes, and
+// the session; genTF executes the template against it.
+type filler struct {
+	IsTrain   bool
+	Estimator string
+	Table     string
+	Columns   []string
+	Label     string
+
+	// ReadQuery/SchemaComment render db's dialect-specific identifier
+	// quoting and native type names (see dialect.go) into the generated
+	// program, so a MySQL source and a PostgreSQL source don't produce
+	// byte-identical Python.
+	ReadQuery     string
+	SchemaComment string
+
+	NumClasses  int
+	HiddenUnits []int
+	BatchSize   int
+	ModelSave   string
+
+	PredictTable  string
+	PredictColumn string
+
+	// String LABEL vocabulary encoding, see label_vocab.go.
+	LabelVocab                []string
+	VocabLookupSnippet        string
+	InverseVocabLookupSnippet string
+
+	// Session storage_config, see storage.go. ArtifactURI/StorageImport are
+	// set for every backend; the rest are backend-specific and only one
+	// group is populated per session.
+	ArtifactURI   string
+	StorageImport string
+
+	// Legacy Hive/HDFS session fields, also populated by an HDFSConfig
+	// storage_config or the pre-storage_config hdfs_* Session fields.
+	HiveLocation     string
+	HDFSNameNodeAddr string
+	HDFSUser         string
+	HDFSPass         string
+
+	// S3 storage_config.
+	S3Endpoint        string
+	S3Region          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+
+	// GCS storage_config.
+	GCSServiceAccountJSON string
+
+	// OSS storage_config.
+	OSSEndpoint        string
+	OSSAccessKeyID     string
+	OSSAccessKeySecret string
+
+	// Snowflake backend, see snowflake.go.
+	Snowflake                  bool
+	SnowflakeAccount           string
+	SnowflakeWarehouse         string
+	SnowflakeRole              string
+	SnowflakeDatabase          string
+	SnowflakeSchema            string
+	SnowflakeConnect           string
+	SnowflakeFetchArrow        bool
+	SnowflakeArrowFetchSnippet string
+}
+
+// clusterConfig configures distributed TensorFlow (worker/PS counts).
+// Single-process training/prediction, which is all the tests in this
+// package exercise, passes nil.
+type clusterConfig struct {
+	Worker int
+	PS     int
+}
+
+// newFiller turns r (plus verify's fts) into the filler genTF's template
+// executes against, pulling in the session's Hive/HDFS and Snowflake
+// configuration when sess is non-nil.
+func newFiller(r *extendedSelect, cc *clusterConfig, fts *fieldTypes, db *DB, sess *pb.Session) (*filler, error) {
+	fl := &filler{
+		Estimator: fts.estimator,
+		Table:     fts.table,
+		Columns:   fts.columns,
+		Label:     fts.label,
+		IsTrain:   r.predictClause == nil,
+	}
+
+	d := dialect(db.driverName)
+	types := make(map[string]columnType, len(fts.columns))
+	for _, c := range fts.columns {
+		if t, ok := db.column(fts.table, c); ok {
+			types[c] = t
+		}
+	}
+	fl.ReadQuery = readQuery(d, fts.table, fts.columns)
+	fl.SchemaComment = schemaComment(d, fts.columns, types)
+
+	if r.trainClause != nil {
+		tc := r.trainClause
+		fl.ModelSave = tc.into
+		if f, ok := tc.attrs["model.n_classes"].(float64); ok {
+			fl.NumClasses = int(f)
+		}
+		if list, ok := tc.attrs["model.hidden_units"].([]interface{}); ok {
+			for _, item := range list {
+				if f, ok := item.(float64); ok {
+					fl.HiddenUnits = append(fl.HiddenUnits, int(f))
+				}
+			}
+		}
+		if f, ok := tc.attrs["train.batch_size"].(float64); ok {
+			fl.BatchSize = int(f)
+		}
+	}
+
+	if r.predictClause != nil {
+		fl.PredictTable = r.predictClause.table
+		fl.PredictColumn = r.predictClause.column
+	}
+
+	if len(fts.labelVocab) > 0 {
+		fl.LabelVocab = fts.labelVocab
+		fl.NumClasses = len(fts.labelVocab)
+		fl.VocabLookupSnippet = vocabLookupTable("label_vocab_table", fts.labelVocab)
+		fl.InverseVocabLookupSnippet = inverseVocabLookupTable("inverse_label_vocab_table", fts.labelVocab)
+	}
+
+	if sess != nil {
+		if e := fillStorage(fl, sess); e != nil {
+			return nil, e
+		}
+		fillSnowflake(fl, sess)
+	}
+
+	return fl, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+)
+
+// defaultMaxVocab is the label.max_vocab ceiling used when the WITH clause
+// doesn't set one. It exists so a LABEL column that turns out to hold
+// near-unique strings (e.g. an ID column mistakenly used as a label) fails
+// fast at verify time instead of generating a huge, useless lookup table.
+const defaultMaxVocab = 1000
+
+// labelVocabulary scans the distinct values of a string LABEL column,
+// already sorted by DB.distinctStrings so the vocabulary index (and
+// therefore n_classes) codegen derives from it is deterministic across
+// verify calls. It errors once the number of distinct values exceeds
+// maxVocab, since a vocabulary that large is almost always a modeling
+// mistake rather than an intentional classifier.
+func labelVocabulary(db *DB, table, column string, maxVocab int) ([]string, error) {
+	if maxVocab <= 0 {
+		maxVocab = defaultMaxVocab
+	}
+	vocab := db.distinctStrings(table, column)
+	if len(vocab) > maxVocab {
+		return nil, fmt.Errorf("label column %s.%s has %d distinct values, exceeding label.max_vocab=%d", table, column, len(vocab), maxVocab)
+	}
+	return vocab, nil
+}
+
+// pyStringList renders vocab as a Python list literal of strings, via the
+// same JSON-based rendering codegen.go's pyList uses for template slices.
+func pyStringList(vocab []string) string {
+	return pyList(vocab)
+}
+
+// vocabLookupTable renders the tf.lookup.StaticHashTable the generated
+// train script uses to map the original string label to its vocabulary
+// index (n_classes is len(vocab)).
+func vocabLookupTable(pyVar string, vocab []string) string {
+	return fmt.Sprintf(`%s = tf.lookup.StaticHashTable(
+    tf.lookup.KeyValueTensorInitializer(%s, list(range(%d))), -1)`,
+		pyVar, pyStringList(vocab), len(vocab))
+}
+
+// inverseVocabLookupTable renders the companion table the generated
+// PREDICT script uses to translate a predicted class index back to the
+// original string label before it is written out.
+func inverseVocabLookupTable(pyVar string, vocab []string) string {
+	return fmt.Sprintf(`%s = tf.lookup.StaticHashTable(
+    tf.lookup.KeyValueTensorInitializer(list(range(%d)), %s), "")`,
+		pyVar, len(vocab), pyStringList(vocab))
+}
@@ -0,0 +1,302 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sourcePos is a 1-based line/column location in the original SQL text, so
+// a WITH attribute parsed out of a statement can be reported back to the
+// user in terms of where it appears in what they wrote, not just its
+// dotted key.
+type sourcePos struct {
+	Line int
+	Col  int
+}
+
+func (p sourcePos) String() string {
+	return fmt.Sprintf("line %d, column %d", p.Line, p.Col)
+}
+
+// offsetToPos converts a byte offset into sql into a 1-based line/column
+// sourcePos.
+func offsetToPos(sql string, offset int) sourcePos {
+	line, col := 1, 1
+	for i := 0; i < offset && i < len(sql); i++ {
+		if sql[i] == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return sourcePos{Line: line, Col: col}
+}
+
+// extendedSelect is the parse result of one SQLFlow statement: a standard
+// SELECT ... FROM clause, plus either a TRAIN or a PREDICT extension. A
+// caller chains the two by parsing the TRAIN statement first and copying
+// its trainClause onto the PREDICT statement's extendedSelect, so verify
+// and genTF can see which estimator/columns/label produced the model being
+// predicted with.
+type extendedSelect struct {
+	fields        []string
+	tables        []string
+	trainClause   *trainClause
+	predictClause *predictClause
+}
+
+// trainClause is the TRAIN ... WITH ... COLUMN ... [LABEL ...] INTO ...
+// extension of a SELECT statement.
+type trainClause struct {
+	estimator string
+	attrs     map[string]interface{}
+	columns   []string
+	label     string
+	into      string
+
+	// labelVocab is the string LABEL vocabulary verify scans at TRAIN
+	// time (see label_vocab.go). It's stored here, rather than recomputed
+	// from whatever table is being verified, because a caller chains
+	// TRAIN into PREDICT by copying this same trainClause onto the
+	// PREDICT statement's extendedSelect (see the comment above) - the
+	// PREDICT table is a different, typically unlabeled, table, and
+	// re-deriving the vocabulary from it would produce the wrong (or an
+	// empty) mapping from class index back to the original string.
+	labelVocab []string
+
+	// attrPos maps each key of attrs (e.g. "model.n_classes") to where it
+	// appears in the original SQL text, so a schema validation error can
+	// cite a source location instead of just the attribute's dotted key.
+	attrPos map[string]sourcePos
+}
+
+// predictClause is the PREDICT ... USING ... extension of a SELECT
+// statement.
+type predictClause struct {
+	table  string
+	column string
+	model  string
+}
+
+var (
+	reStandardSelect = regexp.MustCompile(`(?is)^\s*SELECT\s+(.+?)\s+FROM\s+([\w.]+)\s*`)
+	reTrainClause    = regexp.MustCompile(`(?is)^TRAIN\s+([\w.]+)\s+WITH\s+(.+?)\s+COLUMN\s+(.+?)(?:\s+LABEL\s+([\w.]+)\s*)?\s+INTO\s+([\w.]+)\s*;?\s*$`)
+	rePredictClause  = regexp.MustCompile(`(?is)^PREDICT\s+([\w.]+)\s+USING\s+([\w.]+)\s*;?\s*$`)
+)
+
+// parser parses a single SQLFlow extended SQL statement. The zero value is
+// ready to use; newParser exists so callers don't depend on parser's
+// internals being a struct at all.
+type parser struct{}
+
+func newParser() *parser {
+	return &parser{}
+}
+
+// Parse parses sql into an extendedSelect. It supports exactly the
+// `SELECT ... FROM ... [TRAIN ... | PREDICT ...]` shapes SQLFlow's
+// extended syntax defines; anything else is a parse error.
+func (p *parser) Parse(sql string) (*extendedSelect, error) {
+	m := reStandardSelect.FindStringSubmatchIndex(sql)
+	if m == nil {
+		return nil, fmt.Errorf("parse: expected SELECT ... FROM ..., got %q", sql)
+	}
+	fieldsPart := sql[m[2]:m[3]]
+	table := sql[m[4]:m[5]]
+	rest := strings.TrimSpace(sql[m[1]:])
+
+	r := &extendedSelect{
+		fields: splitTrim(fieldsPart, ','),
+		tables: []string{table},
+	}
+	if rest == "" {
+		return r, nil
+	}
+
+	if idx := reTrainClause.FindStringSubmatchIndex(rest); idx != nil {
+		group := func(n int) string {
+			if idx[2*n] < 0 {
+				return ""
+			}
+			return rest[idx[2*n]:idx[2*n+1]]
+		}
+		withBody := group(2)
+		attrs, attrOffsets, e := parseAttrs(withBody)
+		if e != nil {
+			return nil, fmt.Errorf("parse: TRAIN WITH clause: %v", e)
+		}
+		// rest is sql[m[1]:] with its leading whitespace trimmed off, so
+		// its first byte sits at this offset into sql; idx[4] then locates
+		// the WITH body within rest, and attrOffsets locates each
+		// attribute within that body - chaining the three gives an
+		// absolute offset into sql for every WITH attribute.
+		restStart := m[1] + strings.Index(sql[m[1]:], rest)
+		withBodyStart := restStart + idx[4]
+		attrPos := make(map[string]sourcePos, len(attrOffsets))
+		for key, off := range attrOffsets {
+			attrPos[key] = offsetToPos(sql, withBodyStart+off)
+		}
+		r.trainClause = &trainClause{
+			estimator: group(1),
+			attrs:     attrs,
+			columns:   splitTrim(group(3), ','),
+			label:     group(4),
+			into:      group(5),
+			attrPos:   attrPos,
+		}
+		return r, nil
+	}
+
+	if pc := rePredictClause.FindStringSubmatch(rest); pc != nil {
+		table, column := splitLast(pc[1], '.')
+		r.predictClause = &predictClause{
+			table:  table,
+			column: column,
+			model:  pc[2],
+		}
+		return r, nil
+	}
+
+	return nil, fmt.Errorf("parse: expected TRAIN or PREDICT after %q, got %q", sql[m[0]:m[1]], rest)
+}
+
+// splitLast splits s on the last occurrence of sep, returning ("", s) if
+// sep doesn't occur. It's used to pull the column off of a PREDICT target
+// like "iris.predict.class" -> ("iris.predict", "class").
+func splitLast(s string, sep byte) (head, tail string) {
+	i := strings.LastIndexByte(s, sep)
+	if i < 0 {
+		return "", s
+	}
+	return s[:i], s[i+1:]
+}
+
+// splitTrim splits s on sep at depth 0 (ignoring sep inside [] or ()) and
+// trims whitespace from each piece, dropping empty pieces.
+func splitTrim(s string, sep byte) []string {
+	var out []string
+	for _, p := range splitTopLevel(s, sep) {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// splitTopLevel splits s on sep, treating anything inside a matching pair
+// of [] or () as opaque so that a WITH attribute like
+// "model.hidden_units = [10, 20]" isn't split on the comma between 10 and
+// 20.
+func splitTopLevel(s string, sep byte) []string {
+	chunks := splitTopLevelIndexed(s, sep)
+	out := make([]string, len(chunks))
+	for i, c := range chunks {
+		out[i] = c.text
+	}
+	return out
+}
+
+// topLevelChunk is one piece of splitTopLevelIndexed's result: its text,
+// plus the byte offset within the original string it started at.
+type topLevelChunk struct {
+	start int
+	text  string
+}
+
+// splitTopLevelIndexed is splitTopLevel, but it also returns each chunk's
+// starting byte offset within s, so parseAttrs can translate a parsed WITH
+// attribute back to its position in the original SQL text.
+func splitTopLevelIndexed(s string, sep byte) []topLevelChunk {
+	var parts []topLevelChunk
+	depth := 0
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		default:
+			if s[i] == sep && depth == 0 {
+				parts = append(parts, topLevelChunk{start: start, text: s[start:i]})
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, topLevelChunk{start: start, text: s[start:]})
+	return parts
+}
+
+// parseAttrs parses a WITH clause body, e.g.
+// "model.n_classes = 3, model.hidden_units = [10, 20]", into a flat map
+// keyed by the dotted attribute name, plus each key's byte offset within
+// s. Values are JSON-compatible types (float64, string, []interface{}) so
+// they can be fed directly to validateEstimatorAttrs.
+func parseAttrs(s string) (map[string]interface{}, map[string]int, error) {
+	attrs := map[string]interface{}{}
+	offsets := map[string]int{}
+	for _, c := range splitTopLevelIndexed(s, ',') {
+		chunk := strings.TrimSpace(c.text)
+		if chunk == "" {
+			continue
+		}
+		i := strings.Index(chunk, "=")
+		if i < 0 {
+			return nil, nil, fmt.Errorf("invalid WITH attribute %q, expected key = value", chunk)
+		}
+		key := strings.TrimSpace(chunk[:i])
+		val, e := parseAttrValue(strings.TrimSpace(chunk[i+1:]))
+		if e != nil {
+			return nil, nil, fmt.Errorf("WITH attribute %s: %v", key, e)
+		}
+		attrs[key] = val
+		offsets[key] = c.start + (len(c.text) - len(strings.TrimLeft(c.text, " \t\n\r")))
+	}
+	return attrs, offsets, nil
+}
+
+func parseAttrValue(s string) (interface{}, error) {
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		var list []interface{}
+		for _, tok := range splitTopLevel(s[1:len(s)-1], ',') {
+			tok = strings.TrimSpace(tok)
+			if tok == "" {
+				continue
+			}
+			v, e := parseAttrScalar(tok)
+			if e != nil {
+				return nil, e
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	}
+	return parseAttrScalar(s)
+}
+
+func parseAttrScalar(s string) (interface{}, error) {
+	if f, e := strconv.ParseFloat(s, 64); e == nil {
+		return f, nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1], nil
+	}
+	return s, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+
+	pb "sqlflow.org/sqlflow/pkg/server/proto"
+)
+
+// fillStorage populates the filler's ArtifactURI and credential block from
+// sess.StorageConfig, falling back to the legacy hdfs_* fields on Session
+// when no storage_config is set so that older clients keep working. The
+// generated Python imports StorageImport and binds ArtifactURI to
+// artifact_uri; it does not itself open that URI or write checkpoints or
+// the exported model to it.
+func fillStorage(fl *filler, sess *pb.Session) error {
+	switch c := sess.GetStorageConfig().(type) {
+	case *pb.Session_Hdfs:
+		fillHDFSStorage(fl, c.Hdfs)
+	case *pb.Session_S3:
+		fillS3Storage(fl, c.S3)
+	case *pb.Session_Gcs:
+		fillGCSStorage(fl, c.Gcs)
+	case *pb.Session_Oss:
+		fillOSSStorage(fl, c.Oss)
+	case nil:
+		if hasLegacyHDFSConfig(sess) {
+			fillLegacyHDFSStorage(fl, sess)
+		}
+	default:
+		return fmt.Errorf("unsupported storage_config type %T", c)
+	}
+	return nil
+}
+
+func fillHDFSStorage(fl *filler, c *pb.HDFSConfig) {
+	fl.ArtifactURI = fmt.Sprintf("hdfs://%s%s", c.GetNamenodeAddr(), c.GetHiveLocation())
+	fl.StorageImport = "sqlflow.io.hdfs"
+	fl.HDFSNameNodeAddr = c.GetNamenodeAddr()
+	fl.HDFSUser = c.GetUser()
+	fl.HDFSPass = c.GetPass()
+	fl.HiveLocation = c.GetHiveLocation()
+}
+
+// hasLegacyHDFSConfig reports whether sess has enough of the pre-
+// storage_config hdfs_* fields set to build an ArtifactURI from, as
+// opposed to simply having no storage_config at all (e.g. a Snowflake-only
+// session). HdfsNamenodeAddr is what the URI's host comes from, so it is
+// the field that actually decides this: a session that sets only
+// HiveLocation, with no namenode to reach it through, is no more useful
+// than one that sets nothing.
+func hasLegacyHDFSConfig(sess *pb.Session) bool {
+	return sess.GetHdfsNamenodeAddr() != ""
+}
+
+// fillLegacyHDFSStorage is the pre-storage_config behavior, kept for
+// clients that still set Session.hdfs_namenode_addr directly.
+func fillLegacyHDFSStorage(fl *filler, sess *pb.Session) {
+	fl.ArtifactURI = fmt.Sprintf("hdfs://%s%s", sess.GetHdfsNamenodeAddr(), sess.GetHiveLocation())
+	fl.StorageImport = "sqlflow.io.hdfs"
+	fl.HDFSNameNodeAddr = sess.GetHdfsNamenodeAddr()
+	fl.HDFSUser = sess.GetHdfsUser()
+	fl.HDFSPass = sess.GetHdfsPass()
+	fl.HiveLocation = sess.GetHiveLocation()
+}
+
+func fillS3Storage(fl *filler, c *pb.S3Config) {
+	fl.ArtifactURI = fmt.Sprintf("s3://%s/%s", c.GetBucket(), c.GetPrefix())
+	fl.StorageImport = "sqlflow.io.s3"
+	fl.S3Endpoint = c.GetEndpoint()
+	fl.S3Region = c.GetRegion()
+	fl.S3AccessKeyID = c.GetAccessKeyId()
+	fl.S3SecretAccessKey = c.GetSecretAccessKey()
+}
+
+func fillGCSStorage(fl *filler, c *pb.GCSConfig) {
+	fl.ArtifactURI = fmt.Sprintf("gs://%s/%s", c.GetBucket(), c.GetPrefix())
+	fl.StorageImport = "sqlflow.io.gcs"
+	fl.GCSServiceAccountJSON = c.GetServiceAccountJson()
+}
+
+func fillOSSStorage(fl *filler, c *pb.OSSConfig) {
+	fl.ArtifactURI = fmt.Sprintf("oss://%s/%s", c.GetBucket(), c.GetPrefix())
+	fl.StorageImport = "sqlflow.io.oss"
+	fl.OSSEndpoint = c.GetEndpoint()
+	fl.OSSAccessKeyID = c.GetAccessKeyId()
+	fl.OSSAccessKeySecret = c.GetAccessKeySecret()
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	pb "sqlflow.org/sqlflow/pkg/server/proto"
+)
+
+// NewMemDB builds an in-memory DB from a schema (table -> column ->
+// logical type, one of "int", "float", "string") and fixture rows. It
+// exists so test harnesses outside this package — notably
+// pkg/sql/codegentest, which replays these fixtures across a dialect
+// matrix — can build the same kind of DB this package's own tests use,
+// without reaching into unexported fields.
+//
+// dsn doubles as the DB's driverName (see dialect.go), so a caller that
+// passes a dialect name such as "mysql" or "postgresql" - as codegentest
+// does, one dialect per FixtureLoader - gets that dialect's identifier
+// quoting and type names out of genTF, not a fixed syntax for everyone.
+func NewMemDB(dsn string, schema map[string]map[string]string, rows map[string][]map[string]interface{}) (*DB, error) {
+	s := make(map[string]map[string]columnType, len(schema))
+	for table, cols := range schema {
+		cts := make(map[string]columnType, len(cols))
+		for col, t := range cols {
+			switch t {
+			case "int":
+				cts[col] = typeInt
+			case "float":
+				cts[col] = typeFloat
+			case "string":
+				cts[col] = typeString
+			default:
+				return nil, fmt.Errorf("NewMemDB: unknown column type %q for %s.%s", t, table, col)
+			}
+		}
+		s[table] = cts
+	}
+	return &DB{driverName: dsn, dsn: dsn, schema: s, rows: rows}, nil
+}
+
+// RunTrainPredict parses, verifies and generates TensorFlow code for a
+// TRAIN statement followed by the PREDICT statement that uses its model,
+// against db, writing the PREDICT program to w. It drives the exact same
+// parser/verify/genTF pipeline pkg/sql's own TestCodeGen* functions do, so
+// a cross-package caller (again, codegentest) exercises real codegen
+// rather than a reimplementation of it.
+func RunTrainPredict(w io.Writer, trainSQL, predictSQL string, db *DB, sess *pb.Session) error {
+	tr, e := newParser().Parse(trainSQL)
+	if e != nil {
+		return fmt.Errorf("parse TRAIN: %v", e)
+	}
+	tfts, e := verify(tr, db)
+	if e != nil {
+		return fmt.Errorf("verify TRAIN: %v", e)
+	}
+	if e := genTF(ioutil.Discard, tr, nil, tfts, db, sess); e != nil {
+		return fmt.Errorf("genTF TRAIN: %v", e)
+	}
+
+	pr, e := newParser().Parse(predictSQL)
+	if e != nil {
+		return fmt.Errorf("parse PREDICT: %v", e)
+	}
+	pr.trainClause = tr.trainClause
+	pfts, e := verify(pr, db)
+	if e != nil {
+		return fmt.Errorf("verify PREDICT: %v", e)
+	}
+	if e := genTF(w, pr, nil, pfts, db, sess); e != nil {
+		return fmt.Errorf("genTF PREDICT: %v", e)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2019 The SQLFlow Authors. All rights reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fieldTypes is verify's output: everything genTF/newFiller need to know
+// about the COLUMN/LABEL fields of a TRAIN clause, resolved against the
+// database schema (and, for a string LABEL, its vocabulary).
+type fieldTypes struct {
+	table     string
+	estimator string
+	columns   []string
+
+	label     string
+	labelType columnType
+
+	// labelVocab is set when labelType is typeString: the sorted, distinct
+	// values the LABEL column takes, which the filler turns into a
+	// tf.lookup.StaticHashTable and n_classes.
+	labelVocab []string
+}
+
+// isClassifier reports whether estimator is expected to take a
+// class-valued LABEL, which is what makes automatic string-label
+// vocabulary encoding meaningful (it isn't, for a regressor or an
+// unsupervised model like DeepEmbeddingClusterModel).
+func isClassifier(estimator string) bool {
+	return strings.Contains(estimator, "Classifier")
+}
+
+// verify resolves r's TRAIN/PREDICT COLUMN and LABEL fields against db's
+// schema and, for a string LABEL, scans its vocabulary (see
+// label_vocab.go). The result is what newFiller/genTF need to render the
+// generated TensorFlow program.
+func verify(r *extendedSelect, db *DB) (*fieldTypes, error) {
+	if len(r.tables) != 1 {
+		return nil, fmt.Errorf("verify: expected exactly one FROM table, got %v", r.tables)
+	}
+	table := r.tables[0]
+	if _, ok := db.schema[table]; !ok {
+		return nil, fmt.Errorf("verify: unknown table %s", table)
+	}
+	if r.trainClause == nil {
+		return nil, fmt.Errorf("verify: statement has neither a TRAIN clause nor a carried-over one from a prior TRAIN")
+	}
+	tc := r.trainClause
+
+	for _, c := range tc.columns {
+		if _, ok := db.column(table, c); !ok {
+			return nil, fmt.Errorf("verify: COLUMN %s not found in table %s", c, table)
+		}
+	}
+
+	if e := validateEstimatorAttrs(tc.estimator, tc.attrs, tc.attrPos); e != nil {
+		return nil, fmt.Errorf("verify: %v", e)
+	}
+
+	fts := &fieldTypes{table: table, estimator: tc.estimator, columns: tc.columns}
+
+	if tc.label != "" {
+		lt, ok := db.column(table, tc.label)
+		if !ok {
+			return nil, fmt.Errorf("verify: LABEL %s not found in table %s", tc.label, table)
+		}
+		fts.label = tc.label
+		fts.labelType = lt
+
+		if lt == typeString {
+			if !isClassifier(tc.estimator) {
+				return nil, fmt.Errorf("unsupported label data type: %s for estimator %s", lt, tc.estimator)
+			}
+			if r.predictClause != nil {
+				// PREDICT reuses the vocabulary TRAIN learned, carried
+				// over on the shared trainClause (see its comment) -
+				// scanning the PREDICT table here would build the wrong
+				// index<->string mapping for this model, or none at all
+				// if the table is empty or unlabeled.
+				if len(tc.labelVocab) == 0 {
+					return nil, fmt.Errorf("verify: no label vocabulary recorded for %s; was its TRAIN statement verified first?", tc.into)
+				}
+				fts.labelVocab = tc.labelVocab
+			} else {
+				maxVocab := defaultMaxVocab
+				if v, ok := tc.attrs["label.max_vocab"]; ok {
+					if f, ok := v.(float64); ok {
+						maxVocab = int(f)
+					}
+				}
+				vocab, e := labelVocabulary(db, table, tc.label, maxVocab)
+				if e != nil {
+					return nil, fmt.Errorf("verify: %v", e)
+				}
+				tc.labelVocab = vocab
+				fts.labelVocab = vocab
+			}
+		}
+	}
+
+	return fts, nil
+}